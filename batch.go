@@ -184,12 +184,20 @@ func (c *Client) CreateBatchWithUploadFile(
 	if request.FileName == "" {
 		request.FileName = "@batchinput.jsonl"
 	}
+
 	var file File
-	file, err = c.CreateFileBytes(ctx, FileBytesRequest{
-		Name:    request.FileName,
-		Bytes:   request.Requests.Marshal(),
-		Purpose: PurposeBatch,
-	})
+	if len(request.Requests) > streamingUploadThreshold {
+		// Large batches are streamed line-by-line so the whole JSONL file is never held in
+		// memory at once; see CreateFileFromReader.
+		file, err = c.CreateFileFromReader(ctx, request.FileName, PurposeBatch,
+			&batchIteratorReader{iter: newSliceBatchRequestIterator(request.Requests)})
+	} else {
+		file, err = c.CreateFileBytes(ctx, FileBytesRequest{
+			Name:    request.FileName,
+			Bytes:   request.Requests.Marshal(),
+			Purpose: PurposeBatch,
+		})
+	}
 	if err != nil {
 		err = errors.Join(ErrUploadBatchFileFailed, err)
 		return
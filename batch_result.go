@@ -0,0 +1,165 @@
+package openai
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// batchResultScannerBufferSize is large enough to hold a single JSONL line containing a full
+// chat completion response; the bufio.Scanner default (64KB) is too small for these.
+const batchResultScannerBufferSize = 10 * 1024 * 1024
+
+var ErrBatchNotFinished = errors.New("batch has no output or error file yet")
+
+type BatchResultResponse struct {
+	StatusCode int             `json:"status_code"`
+	RequestID  string          `json:"request_id"`
+	Body       json.RawMessage `json:"body"`
+}
+
+type BatchResultError struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+// BatchResultLine is a single decoded row of a batch output or error file.
+type BatchResultLine struct {
+	ID       string               `json:"id"`
+	CustomID string               `json:"custom_id"`
+	Response *BatchResultResponse `json:"response"`
+	Error    *BatchResultError    `json:"error"`
+
+	endpoint BatchEndpoint
+}
+
+// ChatCompletion unmarshals Response.Body as a ChatCompletionResponse. It returns an error if the
+// line did not originate from BatchEndpointChatCompletions or carries no response body.
+func (l BatchResultLine) ChatCompletion() (response ChatCompletionResponse, err error) {
+	body, err := l.responseBody(BatchEndpointChatCompletions)
+	if err != nil {
+		return
+	}
+	err = json.Unmarshal(body, &response)
+	return
+}
+
+// Completion unmarshals Response.Body as a CompletionResponse. It returns an error if the line did
+// not originate from BatchEndpointCompletions or carries no response body.
+func (l BatchResultLine) Completion() (response CompletionResponse, err error) {
+	body, err := l.responseBody(BatchEndpointCompletions)
+	if err != nil {
+		return
+	}
+	err = json.Unmarshal(body, &response)
+	return
+}
+
+// Embedding unmarshals Response.Body as an EmbeddingResponse. It returns an error if the line did
+// not originate from BatchEndpointEmbeddings or carries no response body.
+func (l BatchResultLine) Embedding() (response EmbeddingResponse, err error) {
+	body, err := l.responseBody(BatchEndpointEmbeddings)
+	if err != nil {
+		return
+	}
+	err = json.Unmarshal(body, &response)
+	return
+}
+
+func (l BatchResultLine) responseBody(want BatchEndpoint) (json.RawMessage, error) {
+	if l.endpoint != want {
+		return nil, fmt.Errorf("batch result line custom_id %q was not produced by %s", l.CustomID, want)
+	}
+	if l.Response == nil {
+		return nil, fmt.Errorf("batch result line custom_id %q has no response, error: %v", l.CustomID, l.Error)
+	}
+	return l.Response.Body, nil
+}
+
+// BatchResultReader iterates over the lines of a downloaded batch output or error file.
+type BatchResultReader struct {
+	scanner  *bufio.Scanner
+	endpoint BatchEndpoint
+}
+
+func newBatchResultReader(content string, endpoint BatchEndpoint) *BatchResultReader {
+	scanner := bufio.NewScanner(strings.NewReader(content))
+	scanner.Buffer(make([]byte, 0, 64*1024), batchResultScannerBufferSize)
+	return &BatchResultReader{scanner: scanner, endpoint: endpoint}
+}
+
+// Next decodes and returns the next line of results, returning io.EOF once the file is exhausted.
+func (r *BatchResultReader) Next() (line BatchResultLine, err error) {
+	if !r.scanner.Scan() {
+		if err = r.scanner.Err(); err != nil {
+			return
+		}
+		err = io.EOF
+		return
+	}
+
+	err = json.Unmarshal(r.scanner.Bytes(), &line)
+	if err != nil {
+		return
+	}
+	line.endpoint = r.endpoint
+	return
+}
+
+// CollectByCustomID drains the reader, indexing every line by its CustomID.
+func (r *BatchResultReader) CollectByCustomID() (map[string]BatchResultLine, error) {
+	results := make(map[string]BatchResultLine)
+	for {
+		line, err := r.Next()
+		if errors.Is(err, io.EOF) {
+			return results, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		results[line.CustomID] = line
+	}
+}
+
+// DownloadBatchOutput downloads the output file produced by a completed batch and returns a
+// BatchResultReader over its lines.
+func (c *Client) DownloadBatchOutput(ctx context.Context, fileID string, endpoint BatchEndpoint) (*BatchResultReader, error) {
+	content, err := c.GetFileContent(ctx, fileID)
+	if err != nil {
+		return nil, err
+	}
+	return newBatchResultReader(content, endpoint), nil
+}
+
+// DownloadBatchErrors downloads the error file produced by a batch and returns a BatchResultReader
+// over its lines.
+func (c *Client) DownloadBatchErrors(ctx context.Context, fileID string, endpoint BatchEndpoint) (*BatchResultReader, error) {
+	content, err := c.GetFileContent(ctx, fileID)
+	if err != nil {
+		return nil, err
+	}
+	return newBatchResultReader(content, endpoint), nil
+}
+
+// RetrieveBatchResults retrieves a batch and downloads its output file, falling back to the error
+// file when no output was produced. It returns ErrBatchNotFinished if neither file is available yet.
+func (c *Client) RetrieveBatchResults(ctx context.Context, batchID string) (*BatchResultReader, error) {
+	batch, err := c.RetrieveBatch(ctx, batchID)
+	if err != nil {
+		return nil, err
+	}
+
+	endpoint := BatchEndpoint(batch.Endpoint)
+	switch {
+	case batch.OutputFileID != nil:
+		return c.DownloadBatchOutput(ctx, *batch.OutputFileID, endpoint)
+	case batch.ErrorFileID != nil:
+		return c.DownloadBatchErrors(ctx, *batch.ErrorFileID, endpoint)
+	default:
+		return nil, ErrBatchNotFinished
+	}
+}
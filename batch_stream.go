@@ -0,0 +1,222 @@
+package openai
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"mime/multipart"
+	"net/http"
+)
+
+const (
+	// MaxBatchRequestCount is OpenAI's limit on the number of requests in a single batch input file.
+	MaxBatchRequestCount = 50_000
+	// MaxBatchFileBytes is OpenAI's limit on the size of a single batch input file.
+	MaxBatchFileBytes = 100 * 1024 * 1024
+
+	// streamingUploadThreshold is the request count above which CreateBatchWithUploadFile switches
+	// from buffering the whole input file in memory to streaming it line by line.
+	streamingUploadThreshold = 1000
+)
+
+// BatchLineItem is a single already-marshaled line of a batch input file.
+type BatchLineItem struct {
+	Line []byte
+}
+
+// BatchRequestIterator lazily produces the lines of a batch input file, so large batches never
+// need to be fully materialized in memory. Next returns io.EOF once exhausted.
+type BatchRequestIterator interface {
+	Next() (BatchLineItem, error)
+}
+
+type sliceBatchRequestIterator struct {
+	requests BatchRequestFiles
+	i        int
+}
+
+func newSliceBatchRequestIterator(requests BatchRequestFiles) *sliceBatchRequestIterator {
+	return &sliceBatchRequestIterator{requests: requests}
+}
+
+func (it *sliceBatchRequestIterator) Next() (BatchLineItem, error) {
+	if it.i >= len(it.requests) {
+		return BatchLineItem{}, io.EOF
+	}
+	item := BatchLineItem{Line: it.requests[it.i].MarshalBatchFile()}
+	it.i++
+	return item, nil
+}
+
+// batchIteratorReader adapts a BatchRequestIterator to an io.Reader, encoding one line at a time
+// so CreateBatchStreaming never buffers the whole input file.
+type batchIteratorReader struct {
+	iter BatchRequestIterator
+	buf  bytes.Buffer
+	done bool
+}
+
+func (r *batchIteratorReader) Read(p []byte) (int, error) {
+	for r.buf.Len() == 0 {
+		if r.done {
+			return 0, io.EOF
+		}
+		item, err := r.iter.Next()
+		if err != nil {
+			r.done = true
+			if errors.Is(err, io.EOF) {
+				return 0, io.EOF
+			}
+			return 0, err
+		}
+		r.buf.Write(item.Line)
+		r.buf.WriteByte('\n')
+	}
+	return r.buf.Read(p)
+}
+
+// CreateFileFromReader uploads purpose-tagged file content read directly from r, streaming it
+// through a multipart body via io.Pipe so the caller's data never needs to be buffered in full
+// before the request is sent.
+func (c *Client) CreateFileFromReader(
+	ctx context.Context,
+	name string,
+	purpose PurposeType,
+	r io.Reader,
+) (file File, err error) {
+	pr, pw := io.Pipe()
+	mw := multipart.NewWriter(pw)
+
+	// Build the request before starting the writer goroutine below: if it errors, pr is closed
+	// and nothing ever blocks waiting for a reader that was never going to arrive.
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.fullURL("/files"), pr)
+	if err != nil {
+		pr.Close()
+		return
+	}
+	req.Header.Set("Content-Type", mw.FormDataContentType())
+
+	go func() {
+		defer pw.Close()
+		defer mw.Close()
+
+		if werr := mw.WriteField("purpose", string(purpose)); werr != nil {
+			pw.CloseWithError(werr)
+			return
+		}
+
+		part, werr := mw.CreateFormFile("file", name)
+		if werr != nil {
+			pw.CloseWithError(werr)
+			return
+		}
+		if _, werr = io.Copy(part, r); werr != nil {
+			pw.CloseWithError(werr)
+		}
+	}()
+
+	err = c.sendRequest(req, &file)
+	return
+}
+
+// CreateBatchStreaming uploads the lines produced by iter and creates a batch against them,
+// without ever holding the whole input file in memory. Use this when the requests come from a
+// lazy source (e.g. a database cursor) rather than an in-memory BatchRequestFiles slice.
+func (c *Client) CreateBatchStreaming(
+	ctx context.Context,
+	fileName string,
+	endpoint BatchEndpoint,
+	iter BatchRequestIterator,
+) (response BatchResponse, err error) {
+	if fileName == "" {
+		fileName = "@batchinput.jsonl"
+	}
+
+	file, err := c.CreateFileFromReader(ctx, fileName, PurposeBatch, &batchIteratorReader{iter: iter})
+	if err != nil {
+		err = errors.Join(ErrUploadBatchFileFailed, err)
+		return
+	}
+
+	response, err = c.CreateBatch(ctx, CreateBatchRequest{
+		InputFileID: file.ID,
+		Endpoint:    endpoint,
+	})
+	return
+}
+
+// BatchSize reports how a set of requests compares against OpenAI's per-file batch limits.
+type BatchSize struct {
+	RequestCount int
+	Bytes        int
+}
+
+// ExceedsLimits reports whether the batch would be rejected by OpenAI for having too many
+// requests or too many bytes in a single input file.
+func (s BatchSize) ExceedsLimits() bool {
+	return s.RequestCount > MaxBatchRequestCount || s.Bytes > MaxBatchFileBytes
+}
+
+// Size computes the request count and byte size of a batch input file, accounting for the
+// newline that Marshal inserts between lines.
+func (r BatchRequestFiles) Size() BatchSize {
+	size := BatchSize{RequestCount: len(r)}
+	for i, req := range r {
+		size.Bytes += len(req.MarshalBatchFile())
+		if i != 0 {
+			size.Bytes++
+		}
+	}
+	return size
+}
+
+// SplitForLimits splits requests into the fewest chunks that each satisfy OpenAI's per-file
+// request-count and byte-size limits.
+func (r BatchRequestFiles) SplitForLimits() []BatchRequestFiles {
+	if !r.Size().ExceedsLimits() {
+		return []BatchRequestFiles{r}
+	}
+
+	var chunks []BatchRequestFiles
+	var current BatchRequestFiles
+	var bytesSoFar int
+	for _, req := range r {
+		lineBytes := len(req.MarshalBatchFile()) + 1
+		if len(current) >= MaxBatchRequestCount || bytesSoFar+lineBytes > MaxBatchFileBytes {
+			if len(current) > 0 {
+				chunks = append(chunks, current)
+			}
+			current = nil
+			bytesSoFar = 0
+		}
+		current = append(current, req)
+		bytesSoFar += lineBytes
+	}
+	if len(current) > 0 {
+		chunks = append(chunks, current)
+	}
+	return chunks
+}
+
+// CreateBatchesWithUploadFile creates one batch per SplitForLimits chunk of request.Requests,
+// so callers with more than 50k requests or 100MB of input don't need to split manually.
+func (c *Client) CreateBatchesWithUploadFile(
+	ctx context.Context,
+	request CreateBatchWithUploadFileRequest,
+) ([]BatchResponse, error) {
+	chunks := request.Requests.SplitForLimits()
+	responses := make([]BatchResponse, 0, len(chunks))
+
+	for _, chunk := range chunks {
+		chunkRequest := request
+		chunkRequest.Requests = chunk
+
+		response, err := c.CreateBatchWithUploadFile(ctx, chunkRequest)
+		if err != nil {
+			return responses, err
+		}
+		responses = append(responses, response)
+	}
+	return responses, nil
+}
@@ -0,0 +1,202 @@
+package openai
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"net/http"
+	"time"
+)
+
+// terminalBatchStatuses are the Batch.Status values after which a batch will never change state again.
+var terminalBatchStatuses = map[string]bool{
+	"completed": true,
+	"failed":    true,
+	"expired":   true,
+	"cancelled": true,
+}
+
+// WaitOptions configures the polling behavior of WaitForBatch.
+type WaitOptions struct {
+	// InitialInterval is the starting point for the exponential backoff between polls. WaitForBatch
+	// calls RetrieveBatch immediately on entry — there is no delay before the first check — so this
+	// only governs the wait after that first poll, and every one after. Defaults to 5 seconds.
+	InitialInterval time.Duration
+	// MaxInterval caps the backoff so polling doesn't fall off arbitrarily far apart. Defaults to
+	// 1 minute.
+	MaxInterval time.Duration
+	// Multiplier is applied to the interval after every poll. Defaults to 1.5.
+	Multiplier float64
+	// ProgressCallback, when set, is invoked with the latest request counts after every poll.
+	ProgressCallback func(BatchRequestCounts)
+	// CancelOnContextDone, when true, calls CancelBatch if ctx is cancelled or times out before
+	// the batch reaches a terminal status. Defaults to false: a caller that merely bounds how
+	// long it waits (e.g. a timeout on one poll among several) should not cancel a batch that's
+	// still running elsewhere.
+	CancelOnContextDone bool
+}
+
+func (o *WaitOptions) withDefaults() WaitOptions {
+	out := WaitOptions{
+		InitialInterval:     5 * time.Second,
+		MaxInterval:         time.Minute,
+		Multiplier:          1.5,
+		ProgressCallback:    o.ProgressCallback,
+		CancelOnContextDone: o.CancelOnContextDone,
+	}
+	if o.InitialInterval > 0 {
+		out.InitialInterval = o.InitialInterval
+	}
+	if o.MaxInterval > 0 {
+		out.MaxInterval = o.MaxInterval
+	}
+	if o.Multiplier > 0 {
+		out.Multiplier = o.Multiplier
+	}
+	return out
+}
+
+// WaitForBatch polls RetrieveBatch until the batch reaches a terminal status (completed, failed,
+// expired, or cancelled), backing off exponentially with jitter between polls. A 429 response is
+// not treated as a failure: WaitForBatch backs off by the response's Retry-After duration (or the
+// current poll interval, if none was given) and keeps polling. If ctx is cancelled or times out
+// before the batch finishes, WaitForBatch returns ctx.Err(); it only calls CancelBatch first when
+// opts.CancelOnContextDone is set.
+func (c *Client) WaitForBatch(ctx context.Context, batchID string, opts WaitOptions) (BatchResponse, error) {
+	opts = opts.withDefaults()
+	interval := opts.InitialInterval
+
+	for {
+		batch, err := c.RetrieveBatch(ctx, batchID)
+		if err != nil {
+			if ctxErr := ctx.Err(); ctxErr != nil {
+				// ctx ended during the poll itself rather than during a sleep.
+				if opts.CancelOnContextDone {
+					if _, cancelErr := c.CancelBatch(context.WithoutCancel(ctx), batchID); cancelErr != nil {
+						return batch, cancelErr
+					}
+				}
+				return batch, ctxErr
+			}
+			wait, rateLimited := rateLimitBackoff(err)
+			if !rateLimited {
+				return batch, err
+			}
+			if wait <= 0 {
+				wait = interval
+			}
+			if waitErr := c.sleepOrStop(ctx, wait, batchID, opts); waitErr != nil {
+				return batch, waitErr
+			}
+			continue
+		}
+
+		if opts.ProgressCallback != nil {
+			opts.ProgressCallback(batch.RequestCounts)
+		}
+
+		if terminalBatchStatuses[batch.Status] {
+			return batch, nil
+		}
+
+		if waitErr := c.sleepOrStop(ctx, jitter(interval), batchID, opts); waitErr != nil {
+			return batch, waitErr
+		}
+
+		interval = time.Duration(float64(interval) * opts.Multiplier)
+		if interval > opts.MaxInterval {
+			interval = opts.MaxInterval
+		}
+	}
+}
+
+// sleepOrStop waits for d or ctx to end, whichever comes first. When ctx ends first, it optionally
+// cancels the batch (per opts.CancelOnContextDone) and returns ctx.Err().
+func (c *Client) sleepOrStop(ctx context.Context, d time.Duration, batchID string, opts WaitOptions) error {
+	select {
+	case <-ctx.Done():
+		if opts.CancelOnContextDone {
+			if _, cancelErr := c.CancelBatch(context.WithoutCancel(ctx), batchID); cancelErr != nil {
+				return cancelErr
+			}
+		}
+		return ctx.Err()
+	case <-time.After(d):
+		return nil
+	}
+}
+
+// rateLimitBackoff reports whether err came from a 429 response and, if the response included a
+// Retry-After header, how long to wait before trying again. A zero duration with rateLimited=true
+// means the caller should fall back to its own backoff interval.
+func rateLimitBackoff(err error) (wait time.Duration, rateLimited bool) {
+	var retryAfterErr *RetryAfterError
+	if errors.As(err, &retryAfterErr) {
+		return retryAfterErr.RetryAfter, true
+	}
+
+	var reqErr *RequestError
+	if errors.As(err, &reqErr) && reqErr.HTTPStatusCode == http.StatusTooManyRequests {
+		return 0, true
+	}
+
+	var apiErr *APIError
+	if errors.As(err, &apiErr) && apiErr.HTTPStatusCode == http.StatusTooManyRequests {
+		return 0, true
+	}
+
+	return 0, false
+}
+
+// jitter returns d plus or minus up to 20%, so many callers polling the same batch don't
+// synchronize their requests.
+func jitter(d time.Duration) time.Duration {
+	delta := float64(d) * 0.2
+	return d + time.Duration(delta*(2*rand.Float64()-1))
+}
+
+// CreateBatchAndWait creates a batch, waits for it to reach a terminal status, and downloads its
+// results. It returns an error if the batch does not complete successfully.
+func (c *Client) CreateBatchAndWait(
+	ctx context.Context,
+	request CreateBatchRequest,
+	opts WaitOptions,
+) (*BatchResultReader, error) {
+	batch, err := c.CreateBatch(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+	return c.waitAndDownload(ctx, batch.ID, opts)
+}
+
+// CreateBatchWithUploadFileAndWait creates a batch from an in-memory set of requests, waits for it
+// to reach a terminal status, and downloads its results. It returns ErrBatchDidNotComplete if the
+// batch finishes as anything other than "completed".
+func (c *Client) CreateBatchWithUploadFileAndWait(
+	ctx context.Context,
+	request CreateBatchWithUploadFileRequest,
+	opts WaitOptions,
+) (*BatchResultReader, error) {
+	batch, err := c.CreateBatchWithUploadFile(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+	return c.waitAndDownload(ctx, batch.ID, opts)
+}
+
+// ErrBatchDidNotComplete is returned by CreateBatchAndWait and CreateBatchWithUploadFileAndWait
+// when the batch reaches a terminal status other than "completed" (i.e. failed, expired, or
+// cancelled).
+var ErrBatchDidNotComplete = errors.New("batch did not complete successfully")
+
+func (c *Client) waitAndDownload(ctx context.Context, batchID string, opts WaitOptions) (*BatchResultReader, error) {
+	batch, err := c.WaitForBatch(ctx, batchID, opts)
+	if err != nil {
+		return nil, err
+	}
+	if batch.Status != "completed" {
+		return nil, fmt.Errorf("%w: status %q", ErrBatchDidNotComplete, batch.Status)
+	}
+	return c.RetrieveBatchResults(ctx, batchID)
+}
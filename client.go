@@ -7,6 +7,7 @@ import (
 	"io"
 	"net/http"
 	"strings"
+	"time"
 )
 
 // Client is OpenAI GPT-3 API client.
@@ -50,27 +51,7 @@ func newClient(config ClientConfig) *Client {
 }
 
 func (c *Client) sendRequest(req *http.Request, v any) error {
-	req.Header.Set("Accept", "application/json; charset=utf-8")
-	// Azure API Key authentication
-	if c.config.APIType == APITypeAzure {
-		req.Header.Set(AzureAPIKeyHeader, c.config.authToken)
-	} else {
-		// OpenAI or Azure AD authentication
-		req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", c.config.authToken))
-	}
-
-	// Check whether Content-Type is already set, Upload Files API requires
-	// Content-Type == multipart/form-data
-	contentType := req.Header.Get("Content-Type")
-	if contentType == "" {
-		req.Header.Set("Content-Type", "application/json; charset=utf-8")
-	}
-
-	if len(c.config.OrgID) > 0 {
-		req.Header.Set("OpenAI-Organization", c.config.OrgID)
-	}
-
-	res, err := c.config.HTTPClient.Do(req)
+	res, err := c.chain(c.doRequest)(req)
 	if err != nil {
 		return err
 	}
@@ -84,6 +65,85 @@ func (c *Client) sendRequest(req *http.Request, v any) error {
 	return decodeResponse(res.Body, v)
 }
 
+// doRequest is the Handler at the bottom of the middleware chain: it performs the actual HTTP
+// round trip via the configured http.Client.
+func (c *Client) doRequest(req *http.Request) (*http.Response, error) {
+	return c.config.HTTPClient.Do(req)
+}
+
+// chain builds the ordered Handler that sendRequest invokes: user-supplied middlewares
+// (outermost, in the order passed to WithMiddleware), wrapping the built-in base middleware
+// (auth, org, and content-type headers), wrapping base.
+func (c *Client) chain(base Handler) Handler {
+	middlewares := make([]Middleware, 0, len(c.config.Middlewares)+1)
+	middlewares = append(middlewares, c.config.Middlewares...)
+	middlewares = append(middlewares, c.baseMiddleware())
+	return chainMiddlewares(middlewares, base)
+}
+
+// baseMiddleware sets the headers every request needs: authentication, the OpenAI-Organization
+// header, and a default Content-Type. It runs innermost, closest to the actual HTTP call, so any
+// outer middleware (e.g. a token-refreshing auth provider) runs first; setAuthHeaders and the
+// Content-Type default both defer to whatever an outer middleware already set.
+func (c *Client) baseMiddleware() Middleware {
+	return func(next Handler) Handler {
+		return func(req *http.Request) (*http.Response, error) {
+			req.Header.Set("Accept", "application/json; charset=utf-8")
+			if err := c.setAuthHeaders(req); err != nil {
+				return nil, err
+			}
+
+			// Check whether Content-Type is already set, Upload Files API requires
+			// Content-Type == multipart/form-data
+			if req.Header.Get("Content-Type") == "" {
+				req.Header.Set("Content-Type", "application/json; charset=utf-8")
+			}
+
+			if len(c.config.OrgID) > 0 {
+				req.Header.Set("OpenAI-Organization", c.config.OrgID)
+			}
+
+			return next(req)
+		}
+	}
+}
+
+// setAuthHeaders injects the appropriate authentication header for the configured API type. It is
+// shared by sendRequest (via baseMiddleware) and newStreamRequest, which does not go through the
+// middleware chain since its SSE connection is consumed elsewhere and must revalidate its token on
+// every call rather than once per long-lived connection.
+//
+// It never overwrites an auth header an outer middleware already set — this is what makes it
+// possible to compose a replacement, e.g. an Azure AD credential middleware. Otherwise, when
+// config.TokenProvider is set, it takes precedence over the static authToken; this is how Azure AD
+// deployments keep their short-lived bearer tokens current without a custom middleware.
+func (c *Client) setAuthHeaders(req *http.Request) error {
+	// Azure API Key authentication
+	if c.config.APIType == APITypeAzure {
+		if req.Header.Get(AzureAPIKeyHeader) != "" {
+			return nil
+		}
+		req.Header.Set(AzureAPIKeyHeader, c.config.authToken)
+		return nil
+	}
+
+	// OpenAI or Azure AD authentication
+	if req.Header.Get("Authorization") != "" {
+		return nil
+	}
+
+	authToken := c.config.authToken
+	if c.config.TokenProvider != nil {
+		token, _, err := c.config.TokenProvider.Token(req.Context())
+		if err != nil {
+			return fmt.Errorf("failed to obtain auth token: %w", err)
+		}
+		authToken = token
+	}
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", authToken))
+	return nil
+}
+
 func decodeResponse(body io.Reader, v any) error {
 	if v == nil {
 		return nil
@@ -133,12 +193,10 @@ func (c *Client) newStreamRequest(
 	req.Header.Set("Connection", "keep-alive")
 
 	// https://learn.microsoft.com/en-us/azure/cognitive-services/openai/reference#authentication
-	// Azure API Key authentication
-	if c.config.APIType == APITypeAzure {
-		req.Header.Set(AzureAPIKeyHeader, c.config.authToken)
-	} else {
-		// OpenAI or Azure AD authentication
-		req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", c.config.authToken))
+	// Revalidated on every call (rather than cached for the connection's lifetime) since SSE
+	// streams are long-lived and an Azure AD token can expire mid-stream.
+	if err := c.setAuthHeaders(req); err != nil {
+		return nil, err
 	}
 	if c.config.OrgID != "" {
 		req.Header.Set("OpenAI-Organization", c.config.OrgID)
@@ -154,8 +212,31 @@ func (c *Client) handleErrorResp(resp *http.Response) error {
 			HTTPStatusCode: resp.StatusCode,
 			Err:            err,
 		}
-		return fmt.Errorf("error, %w", &reqErr)
+		return wrapRetryAfter(resp, fmt.Errorf("error, %w", &reqErr))
 	}
 	errRes.Error.HTTPStatusCode = resp.StatusCode
-	return fmt.Errorf("error, status code: %d, message: %w", resp.StatusCode, errRes.Error)
+	return wrapRetryAfter(resp, fmt.Errorf("error, status code: %d, message: %w", resp.StatusCode, errRes.Error))
+}
+
+// RetryAfterError wraps an error returned for a 429 response that included a Retry-After header,
+// so callers that poll (such as WaitForBatch) can back off by the server's requested amount
+// instead of guessing.
+type RetryAfterError struct {
+	error
+	RetryAfter time.Duration
+}
+
+func (e *RetryAfterError) Unwrap() error { return e.error }
+
+// wrapRetryAfter wraps err in a *RetryAfterError when resp is a 429 that carried a parseable
+// Retry-After header.
+func wrapRetryAfter(resp *http.Response, err error) error {
+	if resp.StatusCode != http.StatusTooManyRequests {
+		return err
+	}
+	wait := retryAfter(resp)
+	if wait <= 0 {
+		return err
+	}
+	return &RetryAfterError{error: err, RetryAfter: wait}
 }
@@ -0,0 +1,257 @@
+package openai
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+const fineTuningJobsSuffix = "/fine_tuning/jobs"
+
+// FineTuningHyperparameter is a fine-tuning hyperparameter value that OpenAI accepts as either the
+// string "auto" or an explicit integer.
+type FineTuningHyperparameter struct {
+	auto bool
+	n    int
+}
+
+// AutoHyperparameter lets OpenAI choose the hyperparameter value automatically.
+func AutoHyperparameter() FineTuningHyperparameter {
+	return FineTuningHyperparameter{auto: true}
+}
+
+// IntHyperparameter pins a hyperparameter to an explicit integer value.
+func IntHyperparameter(n int) FineTuningHyperparameter {
+	return FineTuningHyperparameter{n: n}
+}
+
+func (h FineTuningHyperparameter) MarshalJSON() ([]byte, error) {
+	if h.auto {
+		return json.Marshal("auto")
+	}
+	return json.Marshal(h.n)
+}
+
+func (h *FineTuningHyperparameter) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err == nil {
+		*h = FineTuningHyperparameter{auto: s == "auto"}
+		return nil
+	}
+
+	var n int
+	if err := json.Unmarshal(data, &n); err != nil {
+		return fmt.Errorf("fine tuning hyperparameter must be \"auto\" or an integer: %w", err)
+	}
+	*h = FineTuningHyperparameter{n: n}
+	return nil
+}
+
+// FineTuningFloatHyperparameter is a fine-tuning hyperparameter value that OpenAI accepts as
+// either the string "auto" or an explicit floating-point number, e.g. learning_rate_multiplier.
+type FineTuningFloatHyperparameter struct {
+	auto bool
+	f    float64
+}
+
+// AutoFloatHyperparameter lets OpenAI choose the hyperparameter value automatically.
+func AutoFloatHyperparameter() FineTuningFloatHyperparameter {
+	return FineTuningFloatHyperparameter{auto: true}
+}
+
+// FloatHyperparameter pins a hyperparameter to an explicit value.
+func FloatHyperparameter(f float64) FineTuningFloatHyperparameter {
+	return FineTuningFloatHyperparameter{f: f}
+}
+
+func (h FineTuningFloatHyperparameter) MarshalJSON() ([]byte, error) {
+	if h.auto {
+		return json.Marshal("auto")
+	}
+	return json.Marshal(h.f)
+}
+
+func (h *FineTuningFloatHyperparameter) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err == nil {
+		*h = FineTuningFloatHyperparameter{auto: s == "auto"}
+		return nil
+	}
+
+	var f float64
+	if err := json.Unmarshal(data, &f); err != nil {
+		return fmt.Errorf("fine tuning hyperparameter must be \"auto\" or a number: %w", err)
+	}
+	*h = FineTuningFloatHyperparameter{f: f}
+	return nil
+}
+
+// Hyperparameters are the training hyperparameters for a fine-tuning job. Each field defaults to
+// "auto" when left nil. LearningRateMultiplier is a float (e.g. 1.8), unlike BatchSize and
+// NEpochs, which OpenAI always returns as integers.
+type Hyperparameters struct {
+	BatchSize              *FineTuningHyperparameter      `json:"batch_size,omitempty"`
+	LearningRateMultiplier *FineTuningFloatHyperparameter `json:"learning_rate_multiplier,omitempty"`
+	NEpochs                *FineTuningHyperparameter      `json:"n_epochs,omitempty"`
+}
+
+type FineTuningJobRequest struct {
+	TrainingFile    string           `json:"training_file"`
+	ValidationFile  string           `json:"validation_file,omitempty"`
+	Model           string           `json:"model"`
+	Suffix          string           `json:"suffix,omitempty"`
+	Hyperparameters *Hyperparameters `json:"hyperparameters,omitempty"`
+}
+
+type FineTuningJobError struct {
+	Code    string  `json:"code"`
+	Message string  `json:"message"`
+	Param   *string `json:"param"`
+}
+
+type FineTuningJob struct {
+	ID              string              `json:"id"`
+	Object          string              `json:"object"`
+	CreatedAt       int                 `json:"created_at"`
+	FinishedAt      *int                `json:"finished_at"`
+	Model           string              `json:"model"`
+	FineTunedModel  *string             `json:"fine_tuned_model"`
+	OrganizationID  string              `json:"organization_id"`
+	Status          string              `json:"status"`
+	Hyperparameters Hyperparameters     `json:"hyperparameters"`
+	TrainingFile    string              `json:"training_file"`
+	ValidationFile  *string             `json:"validation_file"`
+	ResultFiles     []string            `json:"result_files"`
+	TrainedTokens   *int                `json:"trained_tokens"`
+	Error           *FineTuningJobError `json:"error"`
+	Suffix          string              `json:"suffix,omitempty"`
+}
+
+type FineTuningJobResponse struct {
+	httpHeader
+	FineTuningJob
+}
+
+type ListFineTuningJobsResponse struct {
+	httpHeader
+	Object  string          `json:"object"`
+	Data    []FineTuningJob `json:"data"`
+	HasMore bool            `json:"has_more"`
+}
+
+type FineTuningJobEvent struct {
+	ID        string `json:"id"`
+	Object    string `json:"object"`
+	CreatedAt int    `json:"created_at"`
+	Level     string `json:"level"`
+	Message   string `json:"message"`
+	Type      string `json:"type,omitempty"`
+}
+
+type ListFineTuningJobEventsResponse struct {
+	httpHeader
+	Object  string               `json:"object"`
+	Data    []FineTuningJobEvent `json:"data"`
+	HasMore bool                 `json:"has_more"`
+}
+
+type ListFineTuningJobEventsParameter struct {
+	After *string
+	Limit *int
+}
+
+// CreateFineTuningJob — API call to create a fine-tuning job.
+func (c *Client) CreateFineTuningJob(
+	ctx context.Context,
+	request FineTuningJobRequest,
+) (response FineTuningJobResponse, err error) {
+	req, err := c.newRequest(ctx, http.MethodPost, c.fullURL(fineTuningJobsSuffix), withBody(request))
+	if err != nil {
+		return
+	}
+
+	err = c.sendRequest(req, &response)
+	return
+}
+
+// RetrieveFineTuningJob — API call to retrieve a fine-tuning job.
+func (c *Client) RetrieveFineTuningJob(ctx context.Context, jobID string) (response FineTuningJobResponse, err error) {
+	urlSuffix := fmt.Sprintf("%s/%s", fineTuningJobsSuffix, jobID)
+	req, err := c.newRequest(ctx, http.MethodGet, c.fullURL(urlSuffix))
+	if err != nil {
+		return
+	}
+
+	err = c.sendRequest(req, &response)
+	return
+}
+
+// CancelFineTuningJob — API call to cancel a fine-tuning job.
+func (c *Client) CancelFineTuningJob(ctx context.Context, jobID string) (response FineTuningJobResponse, err error) {
+	urlSuffix := fmt.Sprintf("%s/%s/cancel", fineTuningJobsSuffix, jobID)
+	req, err := c.newRequest(ctx, http.MethodPost, c.fullURL(urlSuffix))
+	if err != nil {
+		return
+	}
+
+	err = c.sendRequest(req, &response)
+	return
+}
+
+// ListFineTuningJobs — API call to list fine-tuning jobs.
+func (c *Client) ListFineTuningJobs(
+	ctx context.Context,
+	after *string,
+	limit *int,
+) (response ListFineTuningJobsResponse, err error) {
+	urlValues := url.Values{}
+	if limit != nil {
+		urlValues.Add("limit", fmt.Sprintf("%d", *limit))
+	}
+	if after != nil {
+		urlValues.Add("after", *after)
+	}
+	encodedValues := ""
+	if len(urlValues) > 0 {
+		encodedValues = "?" + urlValues.Encode()
+	}
+
+	urlSuffix := fmt.Sprintf("%s%s", fineTuningJobsSuffix, encodedValues)
+	req, err := c.newRequest(ctx, http.MethodGet, c.fullURL(urlSuffix))
+	if err != nil {
+		return
+	}
+
+	err = c.sendRequest(req, &response)
+	return
+}
+
+// ListFineTuningJobEvents — API call to list the events of a fine-tuning job.
+func (c *Client) ListFineTuningJobEvents(
+	ctx context.Context,
+	jobID string,
+	parameter ListFineTuningJobEventsParameter,
+) (response ListFineTuningJobEventsResponse, err error) {
+	urlValues := url.Values{}
+	if parameter.Limit != nil {
+		urlValues.Add("limit", fmt.Sprintf("%d", *parameter.Limit))
+	}
+	if parameter.After != nil {
+		urlValues.Add("after", *parameter.After)
+	}
+	encodedValues := ""
+	if len(urlValues) > 0 {
+		encodedValues = "?" + urlValues.Encode()
+	}
+
+	urlSuffix := fmt.Sprintf("%s/%s/events%s", fineTuningJobsSuffix, jobID, encodedValues)
+	req, err := c.newRequest(ctx, http.MethodGet, c.fullURL(urlSuffix))
+	if err != nil {
+		return
+	}
+
+	err = c.sendRequest(req, &response)
+	return
+}
@@ -0,0 +1,56 @@
+package openai
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+)
+
+var errTestRequestBuilderFailed = errors.New("test request builder failed")
+
+type failingRequestBuilder struct{}
+
+func (*failingRequestBuilder) build(_ context.Context, _, _ string, _ any) (*http.Request, error) {
+	return nil, errTestRequestBuilderFailed
+}
+
+func TestFineTuningJobRequestBuilderErrors(t *testing.T) {
+	client := NewClient("test-token")
+	client.requestBuilder = &failingRequestBuilder{}
+	ctx := context.Background()
+
+	cases := []struct {
+		name string
+		call func() error
+	}{
+		{"CreateFineTuningJob", func() error {
+			_, err := client.CreateFineTuningJob(ctx, FineTuningJobRequest{})
+			return err
+		}},
+		{"RetrieveFineTuningJob", func() error {
+			_, err := client.RetrieveFineTuningJob(ctx, "job-id")
+			return err
+		}},
+		{"CancelFineTuningJob", func() error {
+			_, err := client.CancelFineTuningJob(ctx, "job-id")
+			return err
+		}},
+		{"ListFineTuningJobs", func() error {
+			_, err := client.ListFineTuningJobs(ctx, nil, nil)
+			return err
+		}},
+		{"ListFineTuningJobEvents", func() error {
+			_, err := client.ListFineTuningJobEvents(ctx, "job-id", ListFineTuningJobEventsParameter{})
+			return err
+		}},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if err := tc.call(); !errors.Is(err, errTestRequestBuilderFailed) {
+				t.Errorf("%s did not propagate request builder error: %v", tc.name, err)
+			}
+		})
+	}
+}
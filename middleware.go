@@ -0,0 +1,31 @@
+package openai
+
+import "net/http"
+
+// Handler performs (or forwards) a single HTTP round trip. It is the shape both the terminal
+// request sender and every Middleware wrap.
+type Handler func(req *http.Request) (*http.Response, error)
+
+// Middleware wraps a Handler to add cross-cutting behavior — retries, logging, metrics,
+// authentication — around every request the Client sends.
+type Middleware func(next Handler) Handler
+
+// WithMiddleware appends middlewares to the Client's request chain. Middlewares run in the order
+// they're passed, outermost first, wrapping around the client's built-in base middleware (auth,
+// org, and content-type headers) and the final HTTP call. Use it to compose retry, logging,
+// metrics, or a custom authentication provider (e.g. one that refreshes Azure AD tokens).
+func WithMiddleware(middlewares ...Middleware) Option {
+	return func(c *ClientConfig) {
+		c.Middlewares = append(c.Middlewares, middlewares...)
+	}
+}
+
+// chainMiddlewares wraps base with middlewares in order, so that middlewares[0] is outermost and
+// runs first.
+func chainMiddlewares(middlewares []Middleware, base Handler) Handler {
+	h := base
+	for i := len(middlewares) - 1; i >= 0; i-- {
+		h = middlewares[i](h)
+	}
+	return h
+}
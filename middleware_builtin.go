@@ -0,0 +1,191 @@
+package openai
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// redactedHeaders are never logged in full by LoggingMiddleware.
+var redactedHeaders = map[string]bool{
+	"Authorization": true,
+	"Api-Key":       true,
+}
+
+// RetryMiddleware retries requests that fail with a 429 or 5xx status, backing off exponentially
+// starting from initialInterval and honoring a Retry-After response header when present. It gives
+// up and returns the last response or error after maxRetries retries (maxRetries+1 attempts in all).
+//
+// A request is only retried if its body can be replayed via req.GetBody (as http.NewRequest sets
+// up automatically for []byte/bytes.Buffer/strings.Reader bodies). Requests built from an
+// arbitrary io.Reader — such as the io.Pipe body CreateFileFromReader uses to stream a batch
+// upload without buffering it — have no GetBody and are sent at most once; retrying them would
+// mean buffering the whole body in memory first, defeating the point of streaming it.
+func RetryMiddleware(maxRetries int, initialInterval time.Duration) Middleware {
+	return func(next Handler) Handler {
+		return func(req *http.Request) (*http.Response, error) {
+			if req.Body != nil && req.GetBody == nil {
+				return next(req)
+			}
+
+			interval := initialInterval
+			var resp *http.Response
+			var err error
+			for attempt := 0; ; attempt++ {
+				if attempt > 0 && req.GetBody != nil {
+					body, bodyErr := req.GetBody()
+					if bodyErr != nil {
+						return resp, bodyErr
+					}
+					req.Body = body
+				}
+
+				resp, err = next(req)
+				if err == nil && resp.StatusCode != http.StatusTooManyRequests && resp.StatusCode < http.StatusInternalServerError {
+					return resp, nil
+				}
+				if attempt >= maxRetries {
+					return resp, err
+				}
+
+				wait := retryAfter(resp)
+				if wait == 0 {
+					wait = interval
+				}
+
+				// Drain and close the retried response's body now; nothing else will read it,
+				// and leaving it open would leak the connection.
+				if resp != nil {
+					_, _ = io.Copy(io.Discard, resp.Body)
+					resp.Body.Close()
+				}
+
+				select {
+				case <-req.Context().Done():
+					return resp, req.Context().Err()
+				case <-time.After(wait):
+				}
+				interval *= 2
+			}
+		}
+	}
+}
+
+// retryAfter parses the Retry-After response header as seconds, returning 0 if resp is nil or the
+// header is absent or unparseable.
+func retryAfter(resp *http.Response) time.Duration {
+	if resp == nil {
+		return 0
+	}
+	secs, err := strconv.Atoi(resp.Header.Get("Retry-After"))
+	if err != nil {
+		return 0
+	}
+	return time.Duration(secs) * time.Second
+}
+
+// LoggingMiddleware logs the method, path, status code, and duration of every request, with
+// Authorization and api-key headers redacted.
+func LoggingMiddleware(logger *log.Logger) Middleware {
+	return func(next Handler) Handler {
+		return func(req *http.Request) (*http.Response, error) {
+			start := time.Now()
+			resp, err := next(req)
+			elapsed := time.Since(start)
+
+			if err != nil {
+				logger.Printf("%s %s failed after %s: %v", req.Method, req.URL.Path, elapsed, err)
+				return resp, err
+			}
+			logger.Printf("%s %s -> %d (%s) headers=%v",
+				req.Method, req.URL.Path, resp.StatusCode, elapsed, redactHeaders(req.Header))
+			return resp, nil
+		}
+	}
+}
+
+func redactHeaders(h http.Header) map[string]string {
+	out := make(map[string]string, len(h))
+	for k := range h {
+		if redactedHeaders[k] {
+			out[k] = "[REDACTED]"
+		} else {
+			out[k] = h.Get(k)
+		}
+	}
+	return out
+}
+
+// MetricsCollector receives measurements from MetricsMiddleware. Implementations typically forward
+// these to a Prometheus registry or similar.
+type MetricsCollector interface {
+	ObserveLatency(method, path string, statusCode int, duration time.Duration)
+	ObserveTokenUsage(method, path string, promptTokens, completionTokens int)
+}
+
+// MetricsMiddleware reports request latency and status for every call that returns a response
+// (calls that fail with an error are not reported), and, when the response body
+// carries a "usage" object (chat/completions, completions, embeddings), token counts.
+func MetricsMiddleware(collector MetricsCollector) Middleware {
+	return func(next Handler) Handler {
+		return func(req *http.Request) (*http.Response, error) {
+			start := time.Now()
+			resp, err := next(req)
+			if err != nil {
+				return resp, err
+			}
+
+			body, readErr := io.ReadAll(resp.Body)
+			resp.Body.Close()
+			resp.Body = io.NopCloser(bytes.NewReader(body))
+
+			if readErr == nil {
+				reportTokenUsage(collector, req, body)
+			}
+			collector.ObserveLatency(req.Method, req.URL.Path, resp.StatusCode, time.Since(start))
+			return resp, nil
+		}
+	}
+}
+
+func reportTokenUsage(collector MetricsCollector, req *http.Request, body []byte) {
+	var usage struct {
+		Usage struct {
+			PromptTokens     int `json:"prompt_tokens"`
+			CompletionTokens int `json:"completion_tokens"`
+		} `json:"usage"`
+	}
+	if json.Unmarshal(body, &usage) != nil {
+		return
+	}
+	if usage.Usage.PromptTokens == 0 && usage.Usage.CompletionTokens == 0 {
+		return
+	}
+	collector.ObserveTokenUsage(req.Method, req.URL.Path, usage.Usage.PromptTokens, usage.Usage.CompletionTokens)
+}
+
+// RequestIDMiddleware tags every outgoing request with an X-Request-Id header, generating one if
+// the caller hasn't already set it, so retries and logs of the same logical request can be
+// correlated.
+func RequestIDMiddleware() Middleware {
+	return func(next Handler) Handler {
+		return func(req *http.Request) (*http.Response, error) {
+			if req.Header.Get("X-Request-Id") == "" {
+				req.Header.Set("X-Request-Id", newRequestID())
+			}
+			return next(req)
+		}
+	}
+}
+
+func newRequestID() string {
+	var b [16]byte
+	_, _ = rand.Read(b[:])
+	return hex.EncodeToString(b[:])
+}
@@ -0,0 +1,132 @@
+package openai
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// TokenProvider supplies a bearer token for authenticating requests, along with its expiry, so the
+// client knows when a refresh is due. Set it on ClientConfig to take precedence over a static
+// authToken — this is how long-lived Azure AD deployments keep their ~1 hour tokens current.
+type TokenProvider interface {
+	Token(ctx context.Context) (token string, expiresAt time.Time, err error)
+}
+
+// TokenProviderFunc adapts a function to the TokenProvider interface.
+type TokenProviderFunc func(ctx context.Context) (token string, expiresAt time.Time, err error)
+
+func (f TokenProviderFunc) Token(ctx context.Context) (string, time.Time, error) {
+	return f(ctx)
+}
+
+// CachingTokenProvider wraps a TokenProvider with in-memory caching. It proactively refreshes once
+// 80% of the cached token's TTL has elapsed, and coalesces concurrent callers so only one refresh
+// is in flight against the underlying provider at a time.
+type CachingTokenProvider struct {
+	source TokenProvider
+
+	mu         sync.Mutex
+	token      string
+	issuedAt   time.Time
+	expiresAt  time.Time
+	refreshing chan struct{}
+}
+
+// NewCachingTokenProvider wraps source with proactive refresh and single-flight coalescing.
+func NewCachingTokenProvider(source TokenProvider) *CachingTokenProvider {
+	return &CachingTokenProvider{source: source}
+}
+
+// Token returns the cached token if it's still fresh, otherwise fetches a new one from the
+// underlying source. Concurrent calls made while a refresh is already in flight wait for it to
+// finish rather than triggering their own.
+func (p *CachingTokenProvider) Token(ctx context.Context) (string, time.Time, error) {
+	p.mu.Lock()
+	if p.fresh() {
+		token, expiresAt := p.token, p.expiresAt
+		p.mu.Unlock()
+		return token, expiresAt, nil
+	}
+
+	if wait := p.refreshing; wait != nil {
+		p.mu.Unlock()
+		select {
+		case <-wait:
+			return p.Token(ctx)
+		case <-ctx.Done():
+			return "", time.Time{}, ctx.Err()
+		}
+	}
+
+	done := make(chan struct{})
+	p.refreshing = done
+	p.mu.Unlock()
+
+	token, expiresAt, err := p.source.Token(ctx)
+
+	p.mu.Lock()
+	if err == nil {
+		p.token, p.issuedAt, p.expiresAt = token, time.Now(), expiresAt
+	}
+	p.refreshing = nil
+	p.mu.Unlock()
+	close(done)
+
+	return token, expiresAt, err
+}
+
+// fresh reports whether the cached token is still within 80% of its TTL. Callers must hold p.mu.
+func (p *CachingTokenProvider) fresh() bool {
+	if p.token == "" {
+		return false
+	}
+	ttl := p.expiresAt.Sub(p.issuedAt)
+	if ttl <= 0 {
+		return false
+	}
+	return time.Since(p.issuedAt) < time.Duration(float64(ttl)*0.8)
+}
+
+// AzureADTokenRequestOptions mirrors the Scopes field of azcore/policy.TokenRequestOptions, the
+// only part NewAzureADTokenProvider needs.
+type AzureADTokenRequestOptions struct {
+	Scopes []string
+}
+
+// AzureADAccessToken mirrors azcore.AccessToken, so this module doesn't need to import the Azure
+// SDK just to describe the shape of a token.
+type AzureADAccessToken struct {
+	Token     string
+	ExpiresOn time.Time
+}
+
+// AzureADTokenCredentialAdapter describes the credential NewAzureADTokenProvider needs. It is NOT
+// satisfied by azcore.TokenCredential directly — azcore.TokenCredential.GetToken takes a
+// policy.TokenRequestOptions and returns an azcore.AccessToken, which are distinct named types
+// from AzureADTokenRequestOptions/AzureADAccessToken above, so the method sets don't match. This
+// module doesn't depend on the Azure SDK, so callers wrap their azcore.TokenCredential in a thin
+// shim, e.g.:
+//
+//	type shim struct{ cred azcore.TokenCredential }
+//
+//	func (s shim) GetToken(ctx context.Context, opts openai.AzureADTokenRequestOptions) (openai.AzureADAccessToken, error) {
+//		t, err := s.cred.GetToken(ctx, policy.TokenRequestOptions{Scopes: opts.Scopes})
+//		return openai.AzureADAccessToken{Token: t.Token, ExpiresOn: t.ExpiresOn}, err
+//	}
+type AzureADTokenCredentialAdapter interface {
+	GetToken(ctx context.Context, options AzureADTokenRequestOptions) (AzureADAccessToken, error)
+}
+
+// NewAzureADTokenProvider adapts an AzureADTokenCredentialAdapter into a cached, auto-refreshing
+// TokenProvider for the given scopes.
+func NewAzureADTokenProvider(credential AzureADTokenCredentialAdapter, scopes ...string) *CachingTokenProvider {
+	source := TokenProviderFunc(func(ctx context.Context) (string, time.Time, error) {
+		token, err := credential.GetToken(ctx, AzureADTokenRequestOptions{Scopes: scopes})
+		if err != nil {
+			return "", time.Time{}, err
+		}
+		return token.Token, token.ExpiresOn, nil
+	})
+	return NewCachingTokenProvider(source)
+}